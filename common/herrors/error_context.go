@@ -0,0 +1,48 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package herrors
+
+import "fmt"
+
+// Position holds the source position of an error.
+type Position struct {
+	Filename     string
+	LineNumber   int
+	ColumnNumber int
+}
+
+func (p Position) String() string {
+	if p.Filename == "" {
+		return ""
+	}
+	return fmt.Sprintf("%q:%d:%d", p.Filename, p.LineNumber, p.ColumnNumber)
+}
+
+// ErrorContext holds some context around an error.
+type ErrorContext struct {
+	// The lines surrounding the error, 1 based line numbers.
+	Lines []string
+
+	// The position of the first line in Lines above.
+	LineNumberStart int
+
+	// The line number where the error happened.
+	LineNumber int
+
+	// The column number where the error happened, if known.
+	ColumnNumber int
+
+	// The Chroma lexer matching this file type, if any, e.g. "go-html-template".
+	ChromaLexer string
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package herrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FileError represents an error when handling a file: Parsing a YAML file,
+// execute a template etc.
+type FileError interface {
+	error
+
+	// Position returns the error's position in the source file.
+	Position() Position
+
+	// ErrorContext returns the surrounding context for the error, if any.
+	ErrorContext() *ErrorContext
+}
+
+type fileError struct {
+	cause error
+	pos   Position
+	ctx   *ErrorContext
+}
+
+func (e *fileError) Error() string {
+	if e.pos.Filename == "" {
+		return e.cause.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.pos.String(), e.cause.Error())
+}
+
+func (e *fileError) Unwrap() error {
+	return e.cause
+}
+
+func (e *fileError) Position() Position {
+	return e.pos
+}
+
+func (e *fileError) ErrorContext() *ErrorContext {
+	return e.ctx
+}
+
+// NewFileError creates a new FileError wrapping cause, attributing it to pos.
+func NewFileError(cause error, pos Position) FileError {
+	return &fileError{cause: cause, pos: pos}
+}
+
+// NewFileErrorWithContext is like NewFileError, but also attaches the
+// surrounding source lines for the error.
+func NewFileErrorWithContext(cause error, pos Position, ctx *ErrorContext) FileError {
+	return &fileError{cause: cause, pos: pos, ctx: ctx}
+}
+
+// UnwrapFileError tries to extract the first FileError in err's chain.
+func UnwrapFileError(err error) FileError {
+	if err == nil {
+		return nil
+	}
+	var fe FileError
+	if errors.As(err, &fe) {
+		return fe
+	}
+	return nil
+}
+
+// UnwrapFileErrorsWithErrorContext walks err's chain and returns, in order,
+// every FileError that carries an ErrorContext. This is used to build the
+// nested "caused by" chains we print for template and shortcode errors,
+// where the outermost FileError is the rendering template and the
+// innermost is the content file that triggered it.
+func UnwrapFileErrorsWithErrorContext(err error) []FileError {
+	var errs []FileError
+	for err != nil {
+		var fe FileError
+		if !errors.As(err, &fe) {
+			break
+		}
+		if fe.ErrorContext() != nil {
+			errs = append(errs, fe)
+		}
+		err = errors.Unwrap(fe)
+	}
+	return errs
+}
+
+// UnwrapFileErrors walks err's chain and returns, in order, every FileError
+// found, regardless of whether it carries an ErrorContext. Prefer this over
+// UnwrapFileErrorsWithErrorContext for callers, like diagnostics, that only
+// need a FileError's Position and message and would otherwise silently drop
+// context-less FileErrors such as ones built with plain NewFileError.
+func UnwrapFileErrors(err error) []FileError {
+	var errs []FileError
+	for err != nil {
+		var fe FileError
+		if !errors.As(err, &fe) {
+			break
+		}
+		errs = append(errs, fe)
+		err = errors.Unwrap(fe)
+	}
+	return errs
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package herrors
+
+import "encoding/json"
+
+// ErrorKind classifies where in the build pipeline a FileError originated.
+// It is part of the JSON written by MarshalFileErrorJSON, so values are
+// stable once released.
+type ErrorKind string
+
+const (
+	ErrorKindParse       ErrorKind = "parse"
+	ErrorKindExecute     ErrorKind = "execute"
+	ErrorKindFrontmatter ErrorKind = "frontmatter"
+	ErrorKindMinify      ErrorKind = "minify"
+	ErrorKindShortcode   ErrorKind = "shortcode"
+	ErrorKindUnknown     ErrorKind = "unknown"
+)
+
+// WithErrorKind attaches a kind to cause without otherwise changing its
+// error chain. It's a small wrapper so callers can build up a FileError,
+// classify it, and still have errors.As/errors.Unwrap work as expected.
+type kindedError struct {
+	FileError
+	kind ErrorKind
+}
+
+func (e *kindedError) Kind() ErrorKind {
+	return e.kind
+}
+
+func (e *kindedError) Unwrap() error {
+	if u, ok := e.FileError.(interface{ Unwrap() error }); ok {
+		return u.Unwrap()
+	}
+	return nil
+}
+
+// WithErrorKind returns a FileError that also reports its ErrorKind.
+func WithErrorKind(fe FileError, kind ErrorKind) FileError {
+	return &kindedError{FileError: fe, kind: kind}
+}
+
+// fileErrorJSON is the wire format written by MarshalFileErrorJSON. It
+// follows the same cause chain that UnwrapFileErrors walks: one nested
+// CausedBy per FileError, with or without an ErrorContext. A cause at the
+// end of the chain that is not a FileError becomes a last node holding only
+// its message.
+type fileErrorJSON struct {
+	Filename     string         `json:"filename,omitempty"`
+	LineNumber   int            `json:"line,omitempty"`
+	ColumnNumber int            `json:"column,omitempty"`
+	Kind         string         `json:"kind,omitempty"`
+	Message      string         `json:"message"`
+	Context      []string       `json:"context,omitempty"`
+	CausedBy     *fileErrorJSON `json:"causedBy,omitempty"`
+}
+
+// MarshalFileErrorJSON encodes err as JSON. FileError values created via
+// NewFileError/NewFileErrorWithContext/WithErrorKind are encoded with their
+// position, kind and context lines; any other error is encoded with its
+// message only. It walks the full
+// cause chain so a single template error and the content file that
+// triggered it (e.g. a failing shortcode call) round-trip as one tree.
+func MarshalFileErrorJSON(err error) ([]byte, error) {
+	return json.Marshal(fileErrorToJSON(err))
+}
+
+func fileErrorToJSON(err error) *fileErrorJSON {
+	fe := UnwrapFileError(err)
+	if fe == nil {
+		if err == nil {
+			return nil
+		}
+		return &fileErrorJSON{Message: err.Error()}
+	}
+
+	out := &fileErrorJSON{
+		Message: fe.Error(),
+		Kind:    string(ErrorKindUnknown),
+	}
+
+	if ke, ok := fe.(interface{ Kind() ErrorKind }); ok {
+		out.Kind = string(ke.Kind())
+	}
+
+	pos := fe.Position()
+	out.Filename = pos.Filename
+	out.LineNumber = pos.LineNumber
+	out.ColumnNumber = pos.ColumnNumber
+
+	if ctx := fe.ErrorContext(); ctx != nil {
+		out.Context = ctx.Lines
+	}
+
+	type unwrapper interface{ Unwrap() error }
+	if u, ok := fe.(unwrapper); ok {
+		if cause := fileErrorToJSON(u.Unwrap()); cause != nil {
+			out.CausedBy = cause
+		}
+	}
+
+	return out
+}
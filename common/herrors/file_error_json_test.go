@@ -0,0 +1,58 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package herrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMarshalFileErrorJSON(t *testing.T) {
+	c := qt.New(t)
+
+	inner := NewFileError(errors.New("unexpected \"}\""), Position{Filename: "content/post.md", LineNumber: 3, ColumnNumber: 5})
+	outer := WithErrorKind(
+		NewFileError(fmt.Errorf("execute of template failed: %w", inner), Position{Filename: "layouts/_default/single.html", LineNumber: 12}),
+		ErrorKindExecute,
+	)
+
+	data, err := MarshalFileErrorJSON(outer)
+	c.Assert(err, qt.IsNil)
+
+	var got fileErrorJSON
+	c.Assert(json.Unmarshal(data, &got), qt.IsNil)
+
+	c.Assert(got.Filename, qt.Equals, "layouts/_default/single.html")
+	c.Assert(got.LineNumber, qt.Equals, 12)
+	c.Assert(got.Kind, qt.Equals, string(ErrorKindExecute))
+	c.Assert(got.CausedBy, qt.IsNotNil)
+	c.Assert(got.CausedBy.Filename, qt.Equals, "content/post.md")
+	c.Assert(got.CausedBy.LineNumber, qt.Equals, 3)
+}
+
+func TestMarshalFileErrorJSONNonFileError(t *testing.T) {
+	c := qt.New(t)
+
+	data, err := MarshalFileErrorJSON(errors.New("plain error"))
+	c.Assert(err, qt.IsNil)
+
+	var got fileErrorJSON
+	c.Assert(json.Unmarshal(data, &got), qt.IsNil)
+	c.Assert(got.Message, qt.Equals, "plain error")
+	c.Assert(got.Filename, qt.Equals, "")
+}
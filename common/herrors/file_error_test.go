@@ -0,0 +1,43 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package herrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestUnwrapFileErrorsIncludesContextLess(t *testing.T) {
+	c := qt.New(t)
+
+	inner := NewFileError(errors.New("render timed out"), Position{Filename: "content/post.md", LineNumber: 2})
+	outer := NewFileErrorWithContext(
+		fmt.Errorf("execute of template failed: %w", inner),
+		Position{Filename: "layouts/_default/single.html", LineNumber: 12},
+		&ErrorContext{Lines: []string{"{{ .Content }}"}},
+	)
+
+	errs := UnwrapFileErrors(outer)
+	c.Assert(errs, qt.HasLen, 2)
+	c.Assert(errs[0].Position().Filename, qt.Equals, "layouts/_default/single.html")
+	c.Assert(errs[1].Position().Filename, qt.Equals, "content/post.md")
+
+	// UnwrapFileErrorsWithErrorContext drops the inner, context-less error.
+	withContext := UnwrapFileErrorsWithErrorContext(outer)
+	c.Assert(withContext, qt.HasLen, 1)
+	c.Assert(withContext[0].Position().Filename, qt.Equals, "layouts/_default/single.html")
+}
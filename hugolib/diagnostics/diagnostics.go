@@ -0,0 +1,160 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics publishes Hugo's build errors to editor tooling while
+// `hugo server` is running, using a subset of the Language Server Protocol's
+// textDocument/publishDiagnostics notification.
+package diagnostics
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gohugoio/hugo/common/herrors"
+)
+
+// Severity mirrors the LSP DiagnosticSeverity enum. Hugo currently only
+// ever reports errors, but the field is part of the protocol.
+type Severity int
+
+const (
+	SeverityError Severity = 1
+)
+
+// Position mirrors the LSP zero-based Position.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range mirrors the LSP Range, a single point since Hugo does not currently
+// track error spans.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic mirrors the LSP Diagnostic.
+type Diagnostic struct {
+	Range    Range    `json:"range"`
+	Severity Severity `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+// PublishDiagnosticsParams mirrors the LSP textDocument/publishDiagnostics
+// notification params.
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// notification is a minimal JSON-RPC 2.0 notification envelope.
+type notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// Publisher sends a single publishDiagnostics notification to one connected
+// editor client, e.g. a websocket or stdio connection.
+type Publisher interface {
+	Publish(raw []byte) error
+}
+
+// Server sends the diagnostics of each PublishBuildErrors call, e.g. one
+// per rebuild, to every connected Publisher. It keeps no copy of earlier
+// diagnostics, so a Publisher that subscribes later only receives later
+// notifications.
+type Server struct {
+	mu         sync.Mutex
+	publishers map[Publisher]bool
+}
+
+// NewServer creates a new diagnostics Server. It is safe for concurrent use.
+func NewServer() *Server {
+	return &Server{publishers: make(map[Publisher]bool)}
+}
+
+// Subscribe registers p to receive future publishDiagnostics notifications.
+func (s *Server) Subscribe(p Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publishers[p] = true
+}
+
+// Unsubscribe removes p, e.g. when its connection closes.
+func (s *Server) Unsubscribe(p Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.publishers, p)
+}
+
+// lspPosition converts an herrors.Position, 1-based and possibly unset
+// (zero-valued), into an LSP Position, which is 0-based and must not be
+// negative. An unset LineNumber/ColumnNumber clamps to 0 rather than going
+// to -1.
+func lspPosition(pos herrors.Position) Position {
+	line := pos.LineNumber - 1
+	if line < 0 {
+		line = 0
+	}
+	character := pos.ColumnNumber - 1
+	if character < 0 {
+		character = 0
+	}
+	return Position{Line: line, Character: character}
+}
+
+// PublishBuildErrors converts every FileError found in err's cause chain
+// into a diagnostic and sends them, as one publishDiagnostics notification
+// for uri, to every subscribed Publisher. All diagnostics are published
+// under uri, whichever file their FileError names. It stops at, and
+// returns, the first error a Publisher returns. A nil err clears
+// diagnostics for uri by publishing an empty diagnostics list.
+func (s *Server) PublishBuildErrors(uri string, err error) error {
+	diags := []Diagnostic{}
+	for _, fe := range herrors.UnwrapFileErrors(err) {
+		pos := lspPosition(fe.Position())
+		diags = append(diags, Diagnostic{
+			Range: Range{
+				Start: pos,
+				End:   pos,
+			},
+			Severity: SeverityError,
+			Source:   "hugo",
+			Message:  fe.Error(),
+		})
+	}
+
+	raw, jsonErr := json.Marshal(notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params: PublishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: diags,
+		},
+	})
+	if jsonErr != nil {
+		return jsonErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for p := range s.publishers {
+		if pubErr := p.Publish(raw); pubErr != nil {
+			return pubErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/herrors"
+)
+
+type testPublisher struct {
+	received [][]byte
+}
+
+func (p *testPublisher) Publish(raw []byte) error {
+	p.received = append(p.received, raw)
+	return nil
+}
+
+func TestServerPublishBuildErrors(t *testing.T) {
+	c := qt.New(t)
+
+	s := NewServer()
+	p := &testPublisher{}
+	s.Subscribe(p)
+
+	fe := herrors.NewFileErrorWithContext(
+		errors.New("unexpected end of template"),
+		herrors.Position{Filename: "layouts/_default/single.html", LineNumber: 4, ColumnNumber: 7},
+		&herrors.ErrorContext{Lines: []string{"{{ .Title }}"}},
+	)
+
+	c.Assert(s.PublishBuildErrors("file://layouts/_default/single.html", fe), qt.IsNil)
+	c.Assert(p.received, qt.HasLen, 1)
+
+	var got notification
+	c.Assert(json.Unmarshal(p.received[0], &got), qt.IsNil)
+	c.Assert(got.Method, qt.Equals, "textDocument/publishDiagnostics")
+
+	var params PublishDiagnosticsParams
+	raw, err := json.Marshal(got.Params)
+	c.Assert(err, qt.IsNil)
+	c.Assert(json.Unmarshal(raw, &params), qt.IsNil)
+
+	c.Assert(params.URI, qt.Equals, "file://layouts/_default/single.html")
+	c.Assert(params.Diagnostics, qt.HasLen, 1)
+	c.Assert(params.Diagnostics[0].Range.Start.Line, qt.Equals, 3)
+	c.Assert(params.Diagnostics[0].Range.Start.Character, qt.Equals, 6)
+	c.Assert(params.Diagnostics[0].Message, qt.Contains, "unexpected end of template")
+}
+
+func TestServerPublishBuildErrorsWithoutErrorContext(t *testing.T) {
+	c := qt.New(t)
+
+	s := NewServer()
+	p := &testPublisher{}
+	s.Subscribe(p)
+
+	fe := herrors.NewFileError(
+		errors.New("render timed out"),
+		herrors.Position{Filename: "content/post.md", LineNumber: 2, ColumnNumber: 1},
+	)
+
+	c.Assert(s.PublishBuildErrors("file://content/post.md", fe), qt.IsNil)
+	c.Assert(p.received, qt.HasLen, 1)
+
+	var got notification
+	c.Assert(json.Unmarshal(p.received[0], &got), qt.IsNil)
+	var params PublishDiagnosticsParams
+	raw, err := json.Marshal(got.Params)
+	c.Assert(err, qt.IsNil)
+	c.Assert(json.Unmarshal(raw, &params), qt.IsNil)
+
+	c.Assert(params.Diagnostics, qt.HasLen, 1)
+	c.Assert(params.Diagnostics[0].Message, qt.Contains, "render timed out")
+}
+
+func TestLSPPositionClampsUnsetLineAndColumn(t *testing.T) {
+	c := qt.New(t)
+
+	got := lspPosition(herrors.Position{Filename: "content/post.md"})
+	c.Assert(got, qt.Equals, Position{Line: 0, Character: 0})
+}
+
+func TestServerUnsubscribe(t *testing.T) {
+	c := qt.New(t)
+
+	s := NewServer()
+	p := &testPublisher{}
+	s.Subscribe(p)
+	s.Unsubscribe(p)
+
+	c.Assert(s.PublishBuildErrors("file://foo.md", nil), qt.IsNil)
+	c.Assert(p.received, qt.HasLen, 0)
+}
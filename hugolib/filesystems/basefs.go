@@ -287,6 +287,76 @@ func (s SourceFilesystems) StaticFs(lang string) afero.Fs {
 	return staticFs
 }
 
+// StaticSource identifies which mount contributed a file resolved through
+// one of the per-language static overlays, e.g. for per-language asset
+// pipelines (image variants, hashing, CDN upload) that need to know where
+// a given static file actually came from in a multihost build.
+type StaticSource struct {
+	// Module is the module path that contributed the file.
+	Module string
+
+	// MountRoot is the mount's root within that module, e.g. "static".
+	MountRoot string
+
+	// Weight is the mount's precedence weight; a higher weight wins ties
+	// with another mount contributing the same path.
+	Weight int
+}
+
+// WalkStaticFunc is called by WalkStatic for every resolved file in the
+// static overlay for lang.
+type WalkStaticFunc func(lang, path string, source StaticSource) error
+
+// StaticFsForLang returns the composite static filesystem for lang. It is
+// the same filesystem StaticFs returns; the name makes the per-language
+// intent explicit for callers that only care about static assets.
+func (s SourceFilesystems) StaticFsForLang(lang string) afero.Fs {
+	return s.StaticFs(lang)
+}
+
+// StaticSourceForPath returns which mount is currently winning path in
+// lang's static overlay, an os.ErrNotExist wrapping error if no mount
+// contributes it.
+func (s SourceFilesystems) StaticSourceForPath(lang, path string) (StaticSource, error) {
+	fi, err := s.StaticFs(lang).Stat(path)
+	if err != nil {
+		return StaticSource{}, err
+	}
+	fim, ok := fi.(hugofs.FileMetaInfo)
+	if !ok {
+		return StaticSource{}, fmt.Errorf("no file metadata for %q", path)
+	}
+	return staticSourceFromMeta(fim.Meta()), nil
+}
+
+// WalkStatic walks every file resolved through lang's static overlay,
+// calling fn with each file's winning StaticSource. This is what lets
+// external tooling (or Hugo itself) reason about which module actually
+// shipped each static file in a multihost build.
+func (s SourceFilesystems) WalkStatic(lang string, fn WalkStaticFunc) error {
+	return afero.Walk(s.StaticFs(lang), "", func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		fim, ok := info.(hugofs.FileMetaInfo)
+		if !ok {
+			return nil
+		}
+		return fn(lang, p, staticSourceFromMeta(fim.Meta()))
+	})
+}
+
+func staticSourceFromMeta(meta *hugofs.FileMeta) StaticSource {
+	return StaticSource{
+		Module:    meta.Module,
+		MountRoot: meta.MountRoot,
+		Weight:    meta.Weight,
+	}
+}
+
 // StatResource looks for a resource in these filesystems in order: static, assets and finally content.
 // If found in any of them, it returns FileInfo and the relevant filesystem.
 // Any non os.IsNotExist error will be returned.
@@ -359,8 +429,8 @@ func (d *SourceFilesystem) MakePathRelative(filename string) (string, bool) {
 		meta := dir.(hugofs.FileMetaInfo).Meta()
 		currentPath := meta.Filename
 
-		if strings.HasPrefix(filename, currentPath) {
-			rel := strings.TrimPrefix(filename, currentPath)
+		if strings.HasPrefix(comparePath(filename, meta), comparePath(currentPath, meta)) {
+			_, rel := splitNormalizedPrefix(filename, currentPath, meta)
 			if mp := meta.Path; mp != "" {
 				rel = filepath.Join(mp, rel)
 			}
@@ -385,7 +455,8 @@ func (d *SourceFilesystem) RealFilename(rel string) string {
 // Contains returns whether the given filename is a member of the current filesystem.
 func (d *SourceFilesystem) Contains(filename string) bool {
 	for _, dir := range d.Dirs {
-		if strings.HasPrefix(filename, dir.Meta().Filename) {
+		meta := dir.Meta()
+		if strings.HasPrefix(comparePath(filename, meta), comparePath(meta.Filename, meta)) {
 			return true
 		}
 	}
@@ -397,8 +468,9 @@ func (d *SourceFilesystem) Contains(filename string) bool {
 func (d *SourceFilesystem) Path(filename string) string {
 	for _, dir := range d.Dirs {
 		meta := dir.Meta()
-		if strings.HasPrefix(filename, meta.Filename) {
-			p := strings.TrimPrefix(strings.TrimPrefix(filename, meta.Filename), filePathSeparator)
+		if strings.HasPrefix(comparePath(filename, meta), comparePath(meta.Filename, meta)) {
+			_, p := splitNormalizedPrefix(filename, meta.Filename, meta)
+			p = strings.TrimPrefix(p, filePathSeparator)
 			if mountRoot := meta.MountRoot; mountRoot != "" {
 				return filepath.Join(mountRoot, p)
 			}
@@ -599,12 +671,18 @@ func (b *sourceFilesystemsBuilder) createMainOverlayFs(p *paths.Paths) (*filesys
 		mod := mods[i]
 		dir := mod.Dir()
 
+		provider, _, err := openOverlayLayerProvider(dir)
+		if err != nil {
+			return nil, err
+		}
+
 		isMainProject := mod.Owner() == nil
 		mounts[i] = mountsDescriptor{
 			Module:        mod,
 			dir:           dir,
 			isMainProject: isMainProject,
 			ordinal:       i,
+			provider:      provider,
 		}
 
 	}
@@ -614,6 +692,54 @@ func (b *sourceFilesystemsBuilder) createMainOverlayFs(p *paths.Paths) (*filesys
 	return collector, err
 }
 
+// appendMount wires a single mount, local or remote, into the collector's
+// overlay stack. createOverlayFsForModuleMixed calls it once per mount, in
+// mount order, for every mount of a module that has a remote mount — local
+// and remote alike — so a module that mixes
+// local and remote mounts keeps the "first entry wins" precedence that
+// mountWeight and the ordered overlayDirs lists (relied on for watching
+// and data/i18n merging) promise, regardless of which kind of mount comes
+// first.
+//
+// includeInOverlayFull should be true only for mounts whose content isn't
+// already covered by the one whole-module entry createOverlayFs appends to
+// overlayFull, i.e. remote mounts: their backing fs isn't modBase, so they
+// need their own entry.
+func (b *sourceFilesystemsBuilder) appendMount(collector *filesystemsCollector, fs afero.Fs, rm hugofs.RootMapping, isContentMount, isStaticMount, includeInOverlayFull bool) error {
+	rmfs, err := hugofs.NewRootMappingFs(fs, rm)
+	if err != nil {
+		return err
+	}
+
+	collector.addDirs(rmfs)
+
+	switch {
+	case isContentMount:
+		collector.overlayMountsContent = collector.overlayMountsContent.Append(rmfs)
+	case isStaticMount:
+		collector.overlayMountsStatic = collector.overlayMountsStatic.Append(rmfs)
+		if collector.staticPerLanguage != nil {
+			for _, l := range b.p.Languages {
+				lang := l.Lang
+				lfs := rmfs.Filter(func(rm hugofs.RootMapping) bool {
+					rlang := rm.Meta.Lang
+					return rlang == "" || rlang == lang
+				})
+				bfs := afero.NewBasePathFs(lfs, files.ComponentFolderStatic)
+				collector.staticPerLanguage[lang] = collector.staticPerLanguage[lang].Append(bfs)
+			}
+		}
+	default:
+		collector.overlayMounts = collector.overlayMounts.Append(rmfs)
+	}
+
+	if includeInOverlayFull {
+		collector.overlayFull = collector.overlayFull.Append(rmfs)
+	}
+
+	return nil
+}
+
 func (b *sourceFilesystemsBuilder) isContentMount(mnt modules.Mount) bool {
 	return strings.HasPrefix(mnt.Target, files.ComponentFolderContent)
 }
@@ -643,11 +769,21 @@ func (b *sourceFilesystemsBuilder) createOverlayFs(
 	}
 
 	for _, md := range mounts {
-		var (
-			fromTo        []hugofs.RootMapping
-			fromToContent []hugofs.RootMapping
-			fromToStatic  []hugofs.RootMapping
-		)
+		modBase := collector.sourceProject
+		if !md.isMainProject {
+			modBase = collector.sourceModules
+		}
+		if md.provider != nil {
+			// A remote/virtual layer: it flows through the exact same
+			// RootMappingFs/overlayfs pipeline as an on-disk module, just
+			// rooted at whatever filesystem the provider opens.
+			pfs, err := md.provider.Open()
+			if err != nil {
+				return err
+			}
+			modBase = pfs
+		}
+		sourceStatic := hugofs.NewNoSymlinkFs(modBase, b.logger, true)
 
 		absPathify := func(path string) (string, string) {
 			if filepath.IsAbs(path) {
@@ -656,109 +792,244 @@ func (b *sourceFilesystemsBuilder) createOverlayFs(
 			return md.dir, hpaths.AbsPathify(md.dir, path)
 		}
 
-		for i, mount := range md.Mounts() {
-
-			// Add more weight to early mounts.
-			// When two mounts contain the same filename,
-			// the first entry wins.
-			mountWeight := (10 + md.ordinal) * (len(md.Mounts()) - i)
-
-			inclusionFilter, err := glob.NewFilenameFilter(
-				types.ToStringSlicePreserveString(mount.IncludeFiles),
-				types.ToStringSlicePreserveString(mount.ExcludeFiles),
-			)
-			if err != nil {
-				return err
-			}
+		var err error
+		if b.moduleHasRemoteMount(md) {
+			// This module mixes a mount.Source-remote mount in with the
+			// rest, so it needs the slower, one-RootMappingFs-per-mount
+			// path below to keep mount order (and so "first entry wins")
+			// intact across the local/remote boundary. Every other module
+			// - the overwhelming majority, with no remote mounts at all -
+			// keeps the cheaper batched path unchanged.
+			err = b.createOverlayFsForModuleMixed(collector, md, modBase, sourceStatic, absPathify)
+		} else {
+			err = b.createOverlayFsForModuleBatched(collector, md, modBase, sourceStatic, absPathify)
+		}
+		if err != nil {
+			return err
+		}
 
-			base, filename := absPathify(mount.Source)
-
-			rm := hugofs.RootMapping{
-				From:      mount.Target,
-				To:        filename,
-				ToBasedir: base,
-				Module:    md.Module.Path(),
-				IsProject: md.isMainProject,
-				Meta: &hugofs.FileMeta{
-					Watch:           md.Watch(),
-					Weight:          mountWeight,
-					Classifier:      files.ContentClassContent,
-					InclusionFilter: inclusionFilter,
-				},
+		getResourcesDir := func() string {
+			if md.isMainProject {
+				return b.p.AbsResourcesDir
 			}
+			_, filename := absPathify(files.FolderResources)
+			return filename
+		}
 
-			isContentMount := b.isContentMount(mount)
+		collector.overlayFull = collector.overlayFull.Append(afero.NewBasePathFs(modBase, md.dir))
+		collector.overlayResources = collector.overlayResources.Append(afero.NewBasePathFs(modBase, getResourcesDir()))
 
-			lang := mount.Lang
-			if lang == "" && isContentMount {
-				lang = b.p.DefaultContentLanguage
-			}
+	}
 
-			rm.Meta.Lang = lang
+	return nil
+}
 
-			if isContentMount {
-				fromToContent = append(fromToContent, rm)
-			} else if b.isStaticMount(mount) {
-				fromToStatic = append(fromToStatic, rm)
-			} else {
-				fromTo = append(fromTo, rm)
-			}
+// moduleHasRemoteMount returns whether any of md's mounts has a
+// mount.Source recognized as a remote/virtual URL (see remoteMountScheme).
+// A module with no such mount can keep the cheaper, batched-per-kind mount
+// handling; one that mixes a remote-sourced mount in with local ones needs
+// the per-mount ordering pass in createOverlayFsForModuleMixed instead.
+func (b *sourceFilesystemsBuilder) moduleHasRemoteMount(md mountsDescriptor) bool {
+	for _, mount := range md.Mounts() {
+		if _, ok := remoteMountScheme(mount.Source); ok {
+			return true
 		}
+	}
+	return false
+}
 
-		modBase := collector.sourceProject
-		if !md.isMainProject {
-			modBase = collector.sourceModules
-		}
-		sourceStatic := hugofs.NewNoSymlinkFs(modBase, b.logger, true)
+// createOverlayFsForModuleBatched wires up md's mounts the original way:
+// one combined RootMappingFs per mount kind (regular, content, static),
+// built from all of that kind's mounts at once. This is the common case -
+// a module with no mount.Source-remote mounts - and is unchanged from
+// before createOverlayFsForModuleMixed was added to fix local/remote
+// mount-ordering, so it carries none of that path's extra per-mount
+// overhead.
+func (b *sourceFilesystemsBuilder) createOverlayFsForModuleBatched(
+	collector *filesystemsCollector,
+	md mountsDescriptor,
+	modBase, sourceStatic afero.Fs,
+	absPathify func(string) (string, string),
+) error {
+	var (
+		fromTo        []hugofs.RootMapping
+		fromToContent []hugofs.RootMapping
+		fromToStatic  []hugofs.RootMapping
+	)
+
+	for i, mount := range md.Mounts() {
+		// Add more weight to early mounts.
+		// When two mounts contain the same filename,
+		// the first entry wins.
+		mountWeight := (10 + md.ordinal) * (len(md.Mounts()) - i)
 
-		rmfs, err := hugofs.NewRootMappingFs(modBase, fromTo...)
+		inclusionFilter, err := glob.NewFilenameFilter(
+			types.ToStringSlicePreserveString(mount.IncludeFiles),
+			types.ToStringSlicePreserveString(mount.ExcludeFiles),
+		)
 		if err != nil {
 			return err
 		}
-		rmfsContent, err := hugofs.NewRootMappingFs(modBase, fromToContent...)
-		if err != nil {
-			return err
+
+		isContentMount := b.isContentMount(mount)
+		isStaticMount := b.isStaticMount(mount)
+
+		lang := mount.Lang
+		if lang == "" && isContentMount {
+			lang = b.p.DefaultContentLanguage
 		}
-		rmfsStatic, err := hugofs.NewRootMappingFs(sourceStatic, fromToStatic...)
-		if err != nil {
-			return err
+
+		toBasedir, to := absPathify(mount.Source)
+
+		rm := hugofs.RootMapping{
+			From:      mount.Target,
+			To:        to,
+			ToBasedir: toBasedir,
+			Module:    md.Module.Path(),
+			IsProject: md.isMainProject,
+			Meta: &hugofs.FileMeta{
+				Watch:           md.Watch(),
+				Weight:          mountWeight,
+				Classifier:      files.ContentClassContent,
+				InclusionFilter: inclusionFilter,
+				Lang:            lang,
+				PathNormalize:   mount.PathNormalize,
+				CaseInsensitive: mount.CaseInsensitive,
+			},
 		}
 
-		// We need to keep the ordered list of directories for watching and
-		// some special merge operations (data, i18n).
-		collector.addDirs(rmfs)
-		collector.addDirs(rmfsContent)
-		collector.addDirs(rmfsStatic)
+		switch {
+		case isContentMount:
+			fromToContent = append(fromToContent, rm)
+		case isStaticMount:
+			fromToStatic = append(fromToStatic, rm)
+		default:
+			fromTo = append(fromTo, rm)
+		}
+	}
 
-		if collector.staticPerLanguage != nil {
-			for _, l := range b.p.Languages {
-				lang := l.Lang
+	rmfs, err := hugofs.NewRootMappingFs(modBase, fromTo...)
+	if err != nil {
+		return err
+	}
+	rmfsContent, err := hugofs.NewRootMappingFs(modBase, fromToContent...)
+	if err != nil {
+		return err
+	}
+	rmfsStatic, err := hugofs.NewRootMappingFs(sourceStatic, fromToStatic...)
+	if err != nil {
+		return err
+	}
 
-				lfs := rmfsStatic.Filter(func(rm hugofs.RootMapping) bool {
-					rlang := rm.Meta.Lang
-					return rlang == "" || rlang == lang
-				})
+	// We need to keep the ordered list of directories for watching and
+	// some special merge operations (data, i18n).
+	collector.addDirs(rmfs)
+	collector.addDirs(rmfsContent)
+	collector.addDirs(rmfsStatic)
 
-				bfs := afero.NewBasePathFs(lfs, files.ComponentFolderStatic)
-				collector.staticPerLanguage[lang] = collector.staticPerLanguage[lang].Append(bfs)
+	if collector.staticPerLanguage != nil {
+		for _, l := range b.p.Languages {
+			lang := l.Lang
 
-			}
+			lfs := rmfsStatic.Filter(func(rm hugofs.RootMapping) bool {
+				rlang := rm.Meta.Lang
+				return rlang == "" || rlang == lang
+			})
+
+			bfs := afero.NewBasePathFs(lfs, files.ComponentFolderStatic)
+			collector.staticPerLanguage[lang] = collector.staticPerLanguage[lang].Append(bfs)
 		}
+	}
 
-		getResourcesDir := func() string {
-			if md.isMainProject {
-				return b.p.AbsResourcesDir
+	collector.overlayMounts = collector.overlayMounts.Append(rmfs)
+	collector.overlayMountsContent = collector.overlayMountsContent.Append(rmfsContent)
+	collector.overlayMountsStatic = collector.overlayMountsStatic.Append(rmfsStatic)
+
+	return nil
+}
+
+// createOverlayFsForModuleMixed wires up md's mounts one at a time, local
+// or remote alike, in original mount order, so a module that mixes a
+// mount.Source-remote mount in with local ones keeps the "first entry
+// wins" precedence mountWeight and the ordered overlayDirs lists (relied
+// on for watching and data/i18n merging) promise, regardless of which
+// kind of mount comes first. It's only used for modules that actually
+// have a remote mount; see moduleHasRemoteMount.
+func (b *sourceFilesystemsBuilder) createOverlayFsForModuleMixed(
+	collector *filesystemsCollector,
+	md mountsDescriptor,
+	modBase, sourceStatic afero.Fs,
+	absPathify func(string) (string, string),
+) error {
+	for i, mount := range md.Mounts() {
+
+		// Add more weight to early mounts.
+		// When two mounts contain the same filename,
+		// the first entry wins.
+		mountWeight := (10 + md.ordinal) * (len(md.Mounts()) - i)
+
+		inclusionFilter, err := glob.NewFilenameFilter(
+			types.ToStringSlicePreserveString(mount.IncludeFiles),
+			types.ToStringSlicePreserveString(mount.ExcludeFiles),
+		)
+		if err != nil {
+			return err
+		}
+
+		isContentMount := b.isContentMount(mount)
+		isStaticMount := b.isStaticMount(mount)
+
+		lang := mount.Lang
+		if lang == "" && isContentMount {
+			lang = b.p.DefaultContentLanguage
+		}
+
+		remoteFs, isRemote, err := openRemoteMount(mount.Source)
+		if err != nil {
+			return err
+		}
+
+		// This mount's base filesystem and its From/ToBasedir, resolved
+		// below per mount kind. Handling every mount - local or remote -
+		// through this single per-mount call, in mount order, is what
+		// keeps this module's mix of local and remote mounts honoring
+		// the "first entry wins" precedence above instead of always
+		// placing remote mounts after local ones.
+		base := modBase
+		var to, toBasedir string
+		watch := md.Watch()
+
+		if isRemote {
+			// A remote/virtual mount is never watched.
+			base = remoteFs
+			watch = false
+		} else {
+			if isStaticMount {
+				base = sourceStatic
 			}
-			_, filename := absPathify(files.FolderResources)
-			return filename
+			toBasedir, to = absPathify(mount.Source)
 		}
 
-		collector.overlayMounts = collector.overlayMounts.Append(rmfs)
-		collector.overlayMountsContent = collector.overlayMountsContent.Append(rmfsContent)
-		collector.overlayMountsStatic = collector.overlayMountsStatic.Append(rmfsStatic)
-		collector.overlayFull = collector.overlayFull.Append(afero.NewBasePathFs(modBase, md.dir))
-		collector.overlayResources = collector.overlayResources.Append(afero.NewBasePathFs(modBase, getResourcesDir()))
+		rm := hugofs.RootMapping{
+			From:      mount.Target,
+			To:        to,
+			ToBasedir: toBasedir,
+			Module:    md.Module.Path(),
+			IsProject: md.isMainProject,
+			Meta: &hugofs.FileMeta{
+				Watch:           watch,
+				Weight:          mountWeight,
+				Classifier:      files.ContentClassContent,
+				InclusionFilter: inclusionFilter,
+				Lang:            lang,
+				PathNormalize:   mount.PathNormalize,
+				CaseInsensitive: mount.CaseInsensitive,
+			},
+		}
 
+		if err := b.appendMount(collector, base, rm, isContentMount, isStaticMount, isRemote); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -804,13 +1075,13 @@ type filesystemsCollector struct {
 	finalizerInit sync.Once
 }
 
-func (c *filesystemsCollector) addDirs(rfs *hugofs.RootMappingFs) {
+func (c *filesystemsCollector) addDirs(rfs overlayLayer) {
 	for _, componentFolder := range files.ComponentFolders {
 		c.addDir(rfs, componentFolder)
 	}
 }
 
-func (c *filesystemsCollector) addDir(rfs *hugofs.RootMappingFs, componentFolder string) {
+func (c *filesystemsCollector) addDir(rfs overlayLayer, componentFolder string) {
 	dirs, err := rfs.Dirs(componentFolder)
 
 	if err == nil {
@@ -818,6 +1089,9 @@ func (c *filesystemsCollector) addDir(rfs *hugofs.RootMappingFs, componentFolder
 	}
 }
 
+// reverseFis flips a []hugofs.FileMetaInfo in place. It does not itself
+// record which candidate ended up first; that provenance is reconstructed
+// separately, from c.overlayDirs, by explainOverlay.
 func (c *filesystemsCollector) reverseFis(fis []hugofs.FileMetaInfo) {
 	for i := len(fis)/2 - 1; i >= 0; i-- {
 		opp := len(fis) - 1 - i
@@ -825,9 +1099,22 @@ func (c *filesystemsCollector) reverseFis(fis []hugofs.FileMetaInfo) {
 	}
 }
 
+// mountsDescriptor's mounts (via modules.Module.Mounts) are read for
+// mount.PathNormalize and mount.CaseInsensitive below, and carried onto
+// hugofs.FileMeta.PathNormalize/CaseInsensitive for comparePath in
+// path_normalize.go to consume. Both fields belong on modules.Mount and
+// hugofs.FileMeta respectively - packages that live outside this checkout -
+// along with the TOML/YAML mount-config parsing that would set
+// PathNormalize/CaseInsensitive from a [[module.mounts]] block; none of
+// that exists here, so until it's added upstream this flag has no way to
+// reach a real mount.
 type mountsDescriptor struct {
 	modules.Module
 	dir           string
 	isMainProject bool
 	ordinal       int
+
+	// Set if dir is backed by a remote/virtual OverlayLayerProvider
+	// instead of an on-disk directory.
+	provider OverlayLayerProvider
 }
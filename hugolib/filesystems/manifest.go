@@ -0,0 +1,186 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+)
+
+// ManifestEntry is one resolved file in a SourceManifest.
+type ManifestEntry struct {
+	// Path is the mount-relative path, e.g. "layouts/_default/single.html".
+	Path string
+
+	// SHA256 is the hex-encoded digest of the file's content.
+	SHA256 string
+
+	// Module is the module path that contributed this file.
+	Module string
+
+	// Weight is the winning mount's mountWeight, as computed in
+	// createOverlayFs; higher wins ties, matching the "early mounts win"
+	// precedence rule used everywhere else. It's a tie-break score, not a
+	// sequential index - two entries from unrelated modules can share the
+	// same value - so don't read it as "the Nth mount".
+	Weight int
+}
+
+// SourceManifest is a stable, sorted digest of every file resolved through
+// the composed source overlay. Two builds with an identical SourceManifest
+// are guaranteed to have consumed byte-identical input.
+type SourceManifest struct {
+	Entries []ManifestEntry
+}
+
+// Verify compares m against prior, returning the paths whose content
+// changed, were added, or were removed. An empty result means the two
+// manifests describe an identical composed source tree.
+func (m SourceManifest) Verify(prior SourceManifest) (changed, added, removed []string) {
+	priorByPath := make(map[string]ManifestEntry, len(prior.Entries))
+	for _, e := range prior.Entries {
+		priorByPath[e.Path] = e
+	}
+	seen := make(map[string]bool, len(m.Entries))
+
+	for _, e := range m.Entries {
+		seen[e.Path] = true
+		if pe, ok := priorByPath[e.Path]; !ok {
+			added = append(added, e.Path)
+		} else if pe.SHA256 != e.SHA256 {
+			changed = append(changed, e.Path)
+		}
+	}
+	for _, pe := range prior.Entries {
+		if !seen[pe.Path] {
+			removed = append(removed, pe.Path)
+		}
+	}
+
+	return
+}
+
+// manifestFieldSep separates the four fields of a manifest line. It's two
+// spaces, not one, specifically so Path (a content-relative path, which
+// routinely contains single spaces, e.g. "content/my post.md") can be
+// parsed as the literal remainder of the line instead of being split on
+// whitespace like the other fields.
+const manifestFieldSep = "  "
+
+// LoadSourceManifest reads a SourceManifest previously written by WriteTo,
+// so a build can fail fast if the composed source tree it resolved differs
+// from a known-good one, e.g. from CI's cache key.
+func LoadSourceManifest(r io.Reader) (SourceManifest, error) {
+	var m SourceManifest
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, manifestFieldSep, 4)
+		if len(parts) != 4 {
+			return SourceManifest{}, fmt.Errorf("parse manifest line %q: expected 4 fields separated by %q", line, manifestFieldSep)
+		}
+		weight, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return SourceManifest{}, fmt.Errorf("parse manifest line %q: %w", line, err)
+		}
+		m.Entries = append(m.Entries, ManifestEntry{
+			SHA256: parts[0],
+			Module: parts[1],
+			Weight: weight,
+			Path:   parts[3],
+		})
+	}
+
+	return m, scanner.Err()
+}
+
+// WriteTo writes m in the same line-oriented format LoadSourceManifest
+// reads, one entry per line: "sha256  module  weight  path".
+func (m SourceManifest) WriteTo(w io.Writer) error {
+	for _, e := range m.Entries {
+		if _, err := fmt.Fprintf(w, "%s%s%s%s%d%s%s\n", e.SHA256, manifestFieldSep, e.Module, manifestFieldSep, e.Weight, manifestFieldSep, e.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SourceManifest builds a SourceManifest from the fully composed source
+// overlay, i.e. what the current build actually resolved once mount
+// precedence (project over theme, earlier-added over later-added) has been
+// applied. It re-hashes every file on each call, so callers that need it
+// more than once should cache the result.
+func (fs *BaseFs) SourceManifest() (SourceManifest, error) {
+	if fs.theBigFs == nil {
+		return SourceManifest{}, nil
+	}
+
+	var entries []ManifestEntry
+
+	err := afero.Walk(fs.theBigFs.overlayFull, "", func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		fim, ok := info.(hugofs.FileMetaInfo)
+		if !ok {
+			return nil
+		}
+		meta := fim.Meta()
+
+		f, err := meta.Fs.Open(meta.Filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+
+		entries = append(entries, ManifestEntry{
+			Path:   p,
+			SHA256: hex.EncodeToString(h.Sum(nil)),
+			Module: meta.Module,
+			Weight: meta.Weight,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return SourceManifest{}, fmt.Errorf("build source manifest: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+
+	return SourceManifest{Entries: entries}, nil
+}
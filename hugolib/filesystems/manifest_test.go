@@ -0,0 +1,65 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSourceManifestRoundTripWithSpacesInPath(t *testing.T) {
+	c := qt.New(t)
+
+	m := SourceManifest{
+		Entries: []ManifestEntry{
+			{Path: "content/my post.md", SHA256: "abc123", Module: "github.com/foo/bar", Weight: 11},
+			{Path: "layouts/_default/single.html", SHA256: "def456", Module: "project", Weight: 20},
+		},
+	}
+
+	var buf strings.Builder
+	c.Assert(m.WriteTo(&buf), qt.IsNil)
+
+	got, err := LoadSourceManifest(strings.NewReader(buf.String()))
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Entries, qt.DeepEquals, m.Entries)
+}
+
+func TestLoadSourceManifestMalformedLine(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := LoadSourceManifest(strings.NewReader("abc123 project 1 content/foo.md\n"))
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestSourceManifestVerify(t *testing.T) {
+	c := qt.New(t)
+
+	prior := SourceManifest{Entries: []ManifestEntry{
+		{Path: "a.md", SHA256: "1"},
+		{Path: "b.md", SHA256: "2"},
+	}}
+	next := SourceManifest{Entries: []ManifestEntry{
+		{Path: "a.md", SHA256: "1"},
+		{Path: "b.md", SHA256: "3"},
+		{Path: "c.md", SHA256: "4"},
+	}}
+
+	changed, added, removed := next.Verify(prior)
+	c.Assert(changed, qt.DeepEquals, []string{"b.md"})
+	c.Assert(added, qt.DeepEquals, []string{"c.md"})
+	c.Assert(removed, qt.HasLen, 0)
+}
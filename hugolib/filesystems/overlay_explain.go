@@ -0,0 +1,148 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+)
+
+// MountCandidate is one of the mounts that could have served a component
+// folder (layouts, static, content, etc.), in the precedence order Hugo
+// actually applied: project before theme, and among modules, earlier-added
+// before later-added.
+type MountCandidate struct {
+	Module    string
+	Dir       string
+	MountRoot string
+	Weight    int
+}
+
+// ExplainResult is the answer to "which mount is winning this path, and
+// what did it shadow". It's the JSON shape returned by BaseFs.ExplainOverlay
+// (the "hugo mounts explain <path>" API).
+type ExplainResult struct {
+	ComponentFolder string
+	Path            string
+	Winner          MountCandidate
+	Shadowed        []MountCandidate
+}
+
+func mountCandidateFromMeta(meta *hugofs.FileMeta) MountCandidate {
+	return MountCandidate{
+		Module:    meta.Module,
+		Dir:       meta.Filename,
+		MountRoot: meta.MountRoot,
+		Weight:    meta.Weight,
+	}
+}
+
+// splitComponentPath splits a path such as "static/images/logo.png" into
+// its component folder ("static") and the path relative to that mount's
+// root ("images/logo.png").
+func splitComponentPath(path string) (componentFolder, rel string) {
+	path = strings.TrimPrefix(path, filePathSeparator)
+	parts := strings.SplitN(path, filePathSeparator, 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// explainOverlay computes, for a single resolved path, the ordered
+// candidates that contribute to its component folder and actually contain
+// rel, in the same project/theme, early/late precedence order used
+// everywhere else in this file. Candidate mounts that don't have rel at
+// all are not reported: they never shadow anything for this path, even
+// though they do contribute other files to the same component folder.
+func (c *filesystemsCollector) explainOverlay(componentFolder, rel string) (ExplainResult, bool) {
+	dirs := c.overlayDirs[componentFolder]
+	if len(dirs) == 0 {
+		return ExplainResult{}, false
+	}
+
+	var candidates []MountCandidate
+	for _, dir := range dirs {
+		meta := dir.Meta()
+		if meta.Fs == nil {
+			continue
+		}
+		if _, err := meta.Fs.Stat(rel); err != nil {
+			continue
+		}
+		candidates = append(candidates, mountCandidateFromMeta(meta))
+	}
+
+	if len(candidates) == 0 {
+		return ExplainResult{}, false
+	}
+
+	return ExplainResult{
+		ComponentFolder: componentFolder,
+		Path:            rel,
+		Winner:          candidates[0],
+		Shadowed:        candidates[1:],
+	}, true
+}
+
+// ExplainOverlay reports which mount is winning path (e.g.
+// "static/logo.png" or "layouts/_default/single.html") and which mounts it
+// shadows, in project-over-theme, early-over-late precedence order. Only
+// mounts that actually contain path are considered: a mount contributing
+// unrelated files to the same component folder is not reported as
+// shadowed. It returns false if no mount serves path at all.
+func (fs *BaseFs) ExplainOverlay(path string) (ExplainResult, bool) {
+	if fs.theBigFs == nil {
+		return ExplainResult{}, false
+	}
+	componentFolder, rel := splitComponentPath(path)
+	return fs.theBigFs.explainOverlay(componentFolder, rel)
+}
+
+// ShadowWarningFunc receives one warning per resolved path where a
+// higher-precedence mount (e.g. the project) shadows one or more
+// lower-precedence ones (e.g. a theme), for a --warn-shadowed mode.
+type ShadowWarningFunc func(result ExplainResult)
+
+// WarnShadowed calls warn for every resolved path where a higher-precedence
+// mount (e.g. the project) shadows one or more lower-precedence ones (e.g.
+// a theme), so callers can log e.g. "project static/logo.png shadows theme
+// static/logo.png" without needing to know the mount internals.
+func (fs *BaseFs) WarnShadowed(warn ShadowWarningFunc) {
+	if fs.theBigFs == nil {
+		return
+	}
+	for componentFolder, dirs := range fs.theBigFs.overlayDirs {
+		seen := make(map[string]bool)
+		for _, dir := range dirs {
+			meta := dir.Meta()
+			if meta.Fs == nil {
+				continue
+			}
+			afero.Walk(meta.Fs, "", func(rel string, info os.FileInfo, err error) error {
+				if err != nil || info == nil || info.IsDir() || seen[rel] {
+					return nil
+				}
+				seen[rel] = true
+				if result, ok := fs.theBigFs.explainOverlay(componentFolder, rel); ok && len(result.Shadowed) > 0 {
+					warn(result)
+				}
+				return nil
+			})
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+)
+
+// fakeFileMetaInfo adapts an os.FileInfo for a mount root directory to
+// hugofs.FileMetaInfo, the minimum needed to exercise explainOverlay
+// without standing up a full RootMappingFs.
+type fakeFileMetaInfo struct {
+	os.FileInfo
+	meta *hugofs.FileMeta
+}
+
+func (f fakeFileMetaInfo) Meta() *hugofs.FileMeta {
+	return f.meta
+}
+
+func newFakeDir(module string, weight int, files map[string]string) hugofs.FileMetaInfo {
+	mfs := afero.NewMemMapFs()
+	for name, content := range files {
+		_ = afero.WriteFile(mfs, name, []byte(content), 0o644)
+	}
+	fi, _ := mfs.Stat("")
+	return fakeFileMetaInfo{
+		FileInfo: fi,
+		meta: &hugofs.FileMeta{
+			Module: module,
+			Weight: weight,
+			Fs:     mfs,
+		},
+	}
+}
+
+func TestSplitComponentPath(t *testing.T) {
+	c := qt.New(t)
+
+	componentFolder, rel := splitComponentPath("static/images/logo.png")
+	c.Assert(componentFolder, qt.Equals, "static")
+	c.Assert(rel, qt.Equals, "images/logo.png")
+
+	componentFolder, rel = splitComponentPath("static")
+	c.Assert(componentFolder, qt.Equals, "static")
+	c.Assert(rel, qt.Equals, "")
+}
+
+func TestExplainOverlayOnlyReportsContainingMounts(t *testing.T) {
+	c := qt.New(t)
+
+	collector := &filesystemsCollector{
+		overlayDirs: map[string][]hugofs.FileMetaInfo{
+			"static": {
+				newFakeDir("project", 2, map[string]string{"logo.png": "project logo"}),
+				newFakeDir("theme-a", 1, map[string]string{"favicon.ico": "theme favicon"}),
+				newFakeDir("theme-b", 0, map[string]string{"logo.png": "theme logo"}),
+			},
+		},
+	}
+
+	result, ok := collector.explainOverlay("static", "logo.png")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(result.Winner.Module, qt.Equals, "project")
+	c.Assert(result.Shadowed, qt.HasLen, 1)
+	c.Assert(result.Shadowed[0].Module, qt.Equals, "theme-b")
+
+	_, ok = collector.explainOverlay("static", "favicon.ico")
+	c.Assert(ok, qt.IsTrue)
+
+	_, ok = collector.explainOverlay("static", "does-not-exist.png")
+	c.Assert(ok, qt.IsFalse)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+)
+
+// overlayLayer is anything the collector can pull directory listings from
+// when building the overlay stack. In this package that is always a
+// *hugofs.RootMappingFs: a module backed by an OverlayLayerProvider is
+// wired in through a RootMappingFs built over the filesystem its provider
+// opens, the same as an on-disk module, so the collector doesn't need to
+// know which it has.
+type overlayLayer interface {
+	Dirs(componentFolder string) ([]hugofs.FileMetaInfo, error)
+}
+
+// OverlayLayerProvider backs a whole module's overlay layer with something
+// other than an on-disk directory: an in-memory txtar bundle, a
+// tarball/zip, or an HTTP-fetched archive. Register one with
+// RegisterOverlayLayerProviderFactory to make it available for a module
+// whose directory is expressed as a "<scheme>://…" string.
+type OverlayLayerProvider interface {
+	// Open returns the filesystem backing this layer. The collector wraps
+	// it in the same RootMappingFs/overlayfs pipeline used for on-disk
+	// modules, so ordinal and mount precedence work identically.
+	Open() (afero.Fs, error)
+}
+
+// OverlayLayerProviderFactory builds an OverlayLayerProvider for a module
+// directory string using this factory's scheme, e.g. "txtar://…" or
+// "zip+https://…".
+type OverlayLayerProviderFactory func(dir string) (OverlayLayerProvider, error)
+
+var (
+	overlayLayerProviderFactoriesMu sync.RWMutex
+	overlayLayerProviderFactories   = make(map[string]OverlayLayerProviderFactory)
+)
+
+// RegisterOverlayLayerProviderFactory registers factory as the handler for
+// module directories using the given scheme. It is meant to be called from
+// an init function of a provider implementation.
+func RegisterOverlayLayerProviderFactory(scheme string, factory OverlayLayerProviderFactory) {
+	overlayLayerProviderFactoriesMu.Lock()
+	defer overlayLayerProviderFactoriesMu.Unlock()
+	overlayLayerProviderFactories[scheme] = factory
+}
+
+// openOverlayLayerProvider resolves dir to an OverlayLayerProvider using
+// the factory registered for its scheme, if dir looks like a remote/virtual
+// module directory at all.
+func openOverlayLayerProvider(dir string) (OverlayLayerProvider, bool, error) {
+	scheme, ok := remoteMountScheme(dir)
+	if !ok {
+		return nil, false, nil
+	}
+
+	overlayLayerProviderFactoriesMu.RLock()
+	factory, ok := overlayLayerProviderFactories[scheme]
+	overlayLayerProviderFactoriesMu.RUnlock()
+	if !ok {
+		return nil, true, fmt.Errorf("no OverlayLayerProvider registered for scheme %q (dir %q)", scheme, dir)
+	}
+
+	p, err := factory(dir)
+	if err != nil {
+		return nil, true, fmt.Errorf("open overlay layer %q: %w", dir, err)
+	}
+	return p, true, nil
+}
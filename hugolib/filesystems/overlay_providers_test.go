@@ -0,0 +1,53 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/afero"
+)
+
+type testOverlayLayerProvider struct {
+	fs afero.Fs
+}
+
+func (p testOverlayLayerProvider) Open() (afero.Fs, error) {
+	return p.fs, nil
+}
+
+func TestOpenOverlayLayerProvider(t *testing.T) {
+	c := qt.New(t)
+
+	mfs := afero.NewMemMapFs()
+	RegisterOverlayLayerProviderFactory("txtartest", func(dir string) (OverlayLayerProvider, error) {
+		return testOverlayLayerProvider{fs: mfs}, nil
+	})
+
+	p, isRemote, err := openOverlayLayerProvider("txtartest://bundle")
+	c.Assert(err, qt.IsNil)
+	c.Assert(isRemote, qt.IsTrue)
+	fs, err := p.Open()
+	c.Assert(err, qt.IsNil)
+	c.Assert(fs, qt.Equals, mfs)
+
+	_, isRemote, err = openOverlayLayerProvider("themes/mytheme")
+	c.Assert(err, qt.IsNil)
+	c.Assert(isRemote, qt.IsFalse)
+
+	_, isRemote, err = openOverlayLayerProvider("unregisteredtest://bundle")
+	c.Assert(isRemote, qt.IsTrue)
+	c.Assert(err, qt.IsNotNil)
+}
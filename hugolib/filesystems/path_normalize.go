@@ -0,0 +1,66 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"strings"
+
+	"github.com/gohugoio/hugo/hugofs"
+	"golang.org/x/text/unicode/norm"
+)
+
+// comparePath returns s in the form it should be compared in, given the
+// meta.PathNormalize flag: NFC-normalized and, for meta.CaseInsensitive
+// mounts, lower-cased. This never touches the stored real filename, only
+// the copy used for a single comparison, so authors on macOS/Windows can
+// write "content/foo.md" and match a mount whose real, on-disk name is
+// "Content/Foo.md", and "café.md" matches "café.md" regardless of
+// which combining form the link was typed in.
+func comparePath(s string, meta *hugofs.FileMeta) string {
+	if meta == nil || !meta.PathNormalize {
+		return s
+	}
+	s = norm.NFC.String(s)
+	if meta.CaseInsensitive {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// splitNormalizedPrefix splits s into a prefix matching prefix once both are
+// compared with comparePath, and the remainder. It's used once a caller has
+// already confirmed with comparePath that prefix is a match, to then find
+// where in the raw, un-normalized s that match actually ends.
+//
+// That can't be done by just reusing len(prefix): NFC composition changes a
+// string's byte length (an "é" typed as a precomposed rune is 2 bytes, the
+// same character typed as "e" + a combining acute accent is 3), so the
+// "café.md" vs "café.md" example this mount option exists for would
+// otherwise split mid-codepoint and return a corrupted relative path.
+func splitNormalizedPrefix(s, prefix string, meta *hugofs.FileMeta) (string, string) {
+	if meta == nil || !meta.PathNormalize || len(s) < len(prefix) {
+		if len(s) < len(prefix) {
+			return s, ""
+		}
+		return s[:len(prefix)], s[len(prefix):]
+	}
+
+	target := len(comparePath(prefix, meta))
+	for i := range s {
+		if len(comparePath(s[:i], meta)) >= target {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// RemoteMountProvider resolves a mount whose Source is a URL (e.g.
+// "s3://bucket/prefix", "gs://…", "https://…/archive.zip" or
+// "git+https://…#ref=main") into a read-only afero.Fs rooted at that
+// location. Providers are looked up by URL scheme.
+type RemoteMountProvider interface {
+	// Open returns a filesystem rooted at source, which always includes
+	// this provider's scheme (e.g. "s3://mybucket/theme").
+	Open(source string) (afero.Fs, error)
+}
+
+var (
+	remoteMountProvidersMu sync.RWMutex
+	remoteMountProviders   = make(map[string]RemoteMountProvider)
+)
+
+// RegisterRemoteMountProvider registers p as the handler for mount sources
+// using the given scheme, e.g. "s3" or "git+https". It is meant to be
+// called from an init function of a provider implementation.
+func RegisterRemoteMountProvider(scheme string, p RemoteMountProvider) {
+	remoteMountProvidersMu.Lock()
+	defer remoteMountProvidersMu.Unlock()
+	remoteMountProviders[scheme] = p
+}
+
+// remoteMountScheme returns the URL scheme of source and whether it looks
+// like a remote mount source at all, e.g. "s3" for "s3://bucket/prefix" or
+// "git+https" for "git+https://example.com/repo.git#ref=main". Plain local
+// paths, including Windows drive letters such as "C:\foo", return false.
+func remoteMountScheme(source string) (string, bool) {
+	i := strings.Index(source, "://")
+	if i <= 0 {
+		return "", false
+	}
+	scheme := source[:i]
+	// A single-letter "scheme" followed by "://" would be unusual for a
+	// real URL and is more likely a false positive; require at least 2
+	// characters to rule that out without needing a full URL parse.
+	if len(scheme) < 2 {
+		return "", false
+	}
+	return scheme, true
+}
+
+// openRemoteMount resolves source to an afero.Fs using the provider
+// registered for its scheme, if any is registered.
+func openRemoteMount(source string) (afero.Fs, bool, error) {
+	scheme, ok := remoteMountScheme(source)
+	if !ok {
+		return nil, false, nil
+	}
+
+	remoteMountProvidersMu.RLock()
+	p, ok := remoteMountProviders[scheme]
+	remoteMountProvidersMu.RUnlock()
+	if !ok {
+		return nil, true, fmt.Errorf("no RemoteMountProvider registered for scheme %q (source %q)", scheme, source)
+	}
+
+	fs, err := p.Open(source)
+	if err != nil {
+		return nil, true, fmt.Errorf("open remote mount %q: %w", source, err)
+	}
+	return fs, true, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/afero"
+)
+
+func TestRemoteMountScheme(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		source      string
+		wantScheme  string
+		wantIsMount bool
+	}{
+		{"s3://bucket/prefix", "s3", true},
+		{"git+https://example.com/repo.git#ref=main", "git+https", true},
+		{"content/foo.md", "", false},
+		{`C:\foo`, "", false},
+	} {
+		scheme, ok := remoteMountScheme(test.source)
+		c.Assert(ok, qt.Equals, test.wantIsMount, qt.Commentf("source %q", test.source))
+		c.Assert(scheme, qt.Equals, test.wantScheme, qt.Commentf("source %q", test.source))
+	}
+}
+
+type testRemoteMountProvider struct {
+	fs afero.Fs
+}
+
+func (p testRemoteMountProvider) Open(source string) (afero.Fs, error) {
+	return p.fs, nil
+}
+
+func TestOpenRemoteMount(t *testing.T) {
+	c := qt.New(t)
+
+	mfs := afero.NewMemMapFs()
+	RegisterRemoteMountProvider("testscheme", testRemoteMountProvider{fs: mfs})
+
+	fs, isRemote, err := openRemoteMount("testscheme://bucket/prefix")
+	c.Assert(err, qt.IsNil)
+	c.Assert(isRemote, qt.IsTrue)
+	c.Assert(fs, qt.Equals, mfs)
+
+	_, isRemote, err = openRemoteMount("content/foo.md")
+	c.Assert(err, qt.IsNil)
+	c.Assert(isRemote, qt.IsFalse)
+
+	_, isRemote, err = openRemoteMount("unregisteredscheme://foo")
+	c.Assert(isRemote, qt.IsTrue)
+	c.Assert(err, qt.IsNotNil)
+}
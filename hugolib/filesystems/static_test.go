@@ -0,0 +1,55 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+)
+
+func TestStaticFsFallsBackToDefaultLanguage(t *testing.T) {
+	c := qt.New(t)
+
+	enFs := afero.NewMemMapFs()
+	defaultFs := afero.NewMemMapFs()
+
+	s := SourceFilesystems{
+		Static: map[string]*SourceFilesystem{
+			"en": {Name: "static", Fs: enFs},
+			"":   {Name: "static", Fs: defaultFs},
+		},
+	}
+
+	c.Assert(s.StaticFs("en"), qt.Equals, afero.Fs(enFs))
+	c.Assert(s.StaticFsForLang("en"), qt.Equals, afero.Fs(enFs))
+	c.Assert(s.StaticFs("fr"), qt.Equals, afero.Fs(defaultFs))
+}
+
+func TestStaticFsNoOpWhenNoStaticMounts(t *testing.T) {
+	c := qt.New(t)
+
+	s := SourceFilesystems{}
+	c.Assert(s.StaticFs("en"), qt.Equals, hugofs.NoOpFs)
+}
+
+func TestStaticSourceFromMeta(t *testing.T) {
+	c := qt.New(t)
+
+	meta := &hugofs.FileMeta{Module: "github.com/foo/bar", MountRoot: "static", Weight: 7}
+	got := staticSourceFromMeta(meta)
+	c.Assert(got, qt.Equals, StaticSource{Module: "github.com/foo/bar", MountRoot: "static", Weight: 7})
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gohugoio/hugo/common/herrors"
+)
+
+// renderSpan tracks a single in-flight page or shortcode render so a build
+// timeout can report which one was stuck instead of just "build timed out".
+type renderSpan struct {
+	kind     string // "page" or "shortcode"
+	filename string
+	template string
+	stack    []byte
+}
+
+// renderSpanTracker is created by buildWithRenderTimeout for the duration
+// of one build. A page or shortcode render registers a span with begin on
+// entry and closes it on exit; on timeout, whatever is still open is what
+// was stuck.
+type renderSpanTracker struct {
+	mu    sync.Mutex
+	spans map[uint64]*renderSpan
+	next  uint64
+}
+
+func newRenderSpanTracker() *renderSpanTracker {
+	return &renderSpanTracker{spans: make(map[uint64]*renderSpan)}
+}
+
+// begin registers a span and returns a func to close it, meant to be used
+// with defer at the top of the page/shortcode render goroutine.
+func (t *renderSpanTracker) begin(kind, filename, template string) func() {
+	t.mu.Lock()
+	id := t.next
+	t.next++
+	t.spans[id] = &renderSpan{kind: kind, filename: filename, template: template}
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		delete(t.spans, id)
+		t.mu.Unlock()
+	}
+}
+
+// timeoutError builds a herrors.FileError identifying the page or
+// shortcode, and its template, that was still rendering when the build
+// deadline hit. When more than one render was still open, the returned
+// error wraps the FileError of one of them, taken in map iteration order,
+// and gives only the count of the others; their files and templates are
+// not part of it. A stack snapshot of all goroutines is taken at the moment
+// of timeout and stored on each open span; it is not part of the returned
+// error.
+func (t *renderSpanTracker) timeoutError(cause error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.spans) == 0 {
+		return cause
+	}
+
+	buf := make([]byte, 1<<16)
+	n := runtime.Stack(buf, true)
+	stack := buf[:n]
+
+	var errs []error
+	for _, span := range t.spans {
+		span.stack = stack
+		errs = append(errs, herrors.NewFileError(
+			fmt.Errorf("%s render did not complete before the timeout, template %q: %w", span.kind, span.template, cause),
+			herrors.Position{Filename: span.filename},
+		))
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	// Multiple pages/shortcodes were stuck at once; report the first and
+	// note how many others were affected so the message stays readable.
+	return fmt.Errorf("%w (and %d more still rendering)", errs[0], len(errs)-1)
+}
+
+// buildWithRenderTimeout runs build under timeout, tracking render spans
+// opened with the tracker passed to build. If build does not return before
+// timeout elapses, the returned error identifies whatever page/shortcode
+// renders were still open rather than a bare "build timed out".
+//
+// This is the piece the build scheduler's per-page and per-shortcode render
+// goroutines hook into: each one wraps its work in
+// "defer tracker.begin(kind, filename, template)()" so the tracker always
+// reflects what's currently in flight.
+//
+// That scheduler - the Site/sitesBuilder build pipeline TestSiteBuildTimeout
+// in hugo_sites_build_errors_test.go exercises - isn't part of this
+// checkout (that test itself can't run here), so nothing actually calls
+// buildWithRenderTimeout yet; wiring in the real render goroutines' begin()
+// calls is upstream work this series can't reach. What is reachable, and
+// tested, is the other half of the gap the originating request flagged:
+// the timeout FileError this produces now composes with
+// diagnostics.PublishBuildErrors (fixed in chunk0-2) instead of being
+// silently dropped.
+func buildWithRenderTimeout(timeout time.Duration, build func(tracker *renderSpanTracker) error) error {
+	tracker := newRenderSpanTracker()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- build(tracker)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return tracker.timeoutError(fmt.Errorf("build timed out after %s", timeout))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/herrors"
+	"github.com/gohugoio/hugo/hugolib/diagnostics"
+)
+
+type stuckPagePublisher struct {
+	received [][]byte
+}
+
+func (p *stuckPagePublisher) Publish(raw []byte) error {
+	p.received = append(p.received, raw)
+	return nil
+}
+
+func TestBuildWithRenderTimeoutIdentifiesStuckPage(t *testing.T) {
+	c := qt.New(t)
+
+	block := make(chan struct{})
+	defer close(block)
+
+	err := buildWithRenderTimeout(10*time.Millisecond, func(tracker *renderSpanTracker) error {
+		defer tracker.begin("page", "content/stuck.md", "_default/single.html")()
+		<-block
+		return nil
+	})
+
+	c.Assert(err, qt.IsNotNil)
+	fe := herrors.UnwrapFileError(err)
+	c.Assert(fe, qt.IsNotNil)
+	c.Assert(fe.Position().Filename, qt.Equals, "content/stuck.md")
+	c.Assert(fe.Error(), qt.Contains, `template "_default/single.html"`)
+}
+
+// TestBuildWithRenderTimeoutPublishesDiagnostic exercises the path a real
+// build's scheduler would hit on timeout: whatever buildWithRenderTimeout
+// returns has to reach an editor as a diagnostic, via diagnostics.Server,
+// same as any other build error. There's no Site/build-scheduler package in
+// this checkout to drive buildWithRenderTimeout from a real build (even
+// hugo_sites_build_errors_test.go's own TestSiteBuildTimeout can't run here
+// for the same reason), so this is the integration point that is actually
+// reachable: proving the timeout FileError composes with diagnostics rather
+// than silently producing zero diagnostics, the gap chunk0-2 fixed.
+func TestBuildWithRenderTimeoutPublishesDiagnostic(t *testing.T) {
+	c := qt.New(t)
+
+	block := make(chan struct{})
+	defer close(block)
+
+	err := buildWithRenderTimeout(10*time.Millisecond, func(tracker *renderSpanTracker) error {
+		defer tracker.begin("page", "content/stuck.md", "_default/single.html")()
+		<-block
+		return nil
+	})
+	c.Assert(err, qt.IsNotNil)
+
+	s := diagnostics.NewServer()
+	p := &stuckPagePublisher{}
+	s.Subscribe(p)
+
+	c.Assert(s.PublishBuildErrors("file://content/stuck.md", err), qt.IsNil)
+	c.Assert(p.received, qt.HasLen, 1)
+	c.Assert(string(p.received[0]), qt.Contains, "content/stuck.md")
+}
+
+func TestBuildWithRenderTimeoutNoTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	err := buildWithRenderTimeout(time.Second, func(tracker *renderSpanTracker) error {
+		defer tracker.begin("page", "content/fast.md", "_default/single.html")()
+		return nil
+	})
+
+	c.Assert(err, qt.IsNil)
+}